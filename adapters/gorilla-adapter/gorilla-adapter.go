@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"github.com/gorilla/mux"
 	"github.com/swillbanks-firstorion/crud"
+	"github.com/swillbanks-firstorion/crud/option"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -61,15 +63,35 @@ func (a *Adapter) Install(r *crud.Router, spec *crud.Spec) error {
 	return nil
 }
 
-func (a *Adapter) Serve(swagger *crud.Swagger, addr string) error {
-	a.Engine.HandleFunc("/swagger.json", func(w http.ResponseWriter, r *http.Request) {
-		_ = json.NewEncoder(w).Encode(swagger)
-	})
+func (a *Adapter) Serve(swagger *crud.Swagger, openapi *crud.OpenAPI, addr string) error {
+	if swagger != nil {
+		a.Engine.HandleFunc("/swagger.json", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(swagger)
+		})
+	}
+
+	if openapi != nil {
+		a.Engine.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(openapi)
+		})
+		a.Engine.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+			data, err := crud.MarshalYAML(openapi)
+			if err != nil {
+				w.WriteHeader(500)
+				return
+			}
+			w.Header().Set("content-type", "application/yaml")
+			_, _ = w.Write(data)
+		})
+	}
 
 	a.Engine.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("content-type", "text/html")
-		_, err := w.Write(crud.SwaggerUiTemplate)
-		if err != nil {
+		template := crud.SwaggerUiTemplate
+		if swagger == nil && openapi != nil {
+			template = crud.OpenApiUiTemplate
+		}
+		if _, err := w.Write(template); err != nil {
 			panic(err)
 		}
 	})
@@ -85,6 +107,18 @@ func validateHandlerMiddleware(router *crud.Router, spec *crud.Spec) mux.Middlew
 			var body interface{}
 			var path map[string]string
 
+			if len(spec.Auth) > 0 {
+				active := router.ActiveRoles(r)
+				if !crud.AuthAllowed(spec.Auth, active) {
+					status := http.StatusForbidden
+					if len(active) == 0 {
+						status = http.StatusUnauthorized
+					}
+					w.WriteHeader(status)
+					return
+				}
+			}
+
 			if val.Path.Initialized() {
 				path = map[string]string{}
 				for key, value := range mux.Vars(r) {
@@ -93,8 +127,14 @@ func validateHandlerMiddleware(router *crud.Router, spec *crud.Spec) mux.Middlew
 			}
 
 			var rewriteBody bool
+			var bodyCodec crud.Codec
 			if val.Body.Initialized() && val.Body.Kind() != crud.KindFile {
-				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				bodyCodec = router.Codec(mediaType(r.Header.Get("Content-Type")))
+				data, err := ioutil.ReadAll(r.Body)
+				if err == nil {
+					err = bodyCodec.Decode(data, &body)
+				}
+				if err != nil {
 					w.WriteHeader(400)
 					_ = json.NewEncoder(w).Encode("failure decoding body: " + err.Error())
 					return
@@ -109,17 +149,22 @@ func validateHandlerMiddleware(router *crud.Router, spec *crud.Spec) mux.Middlew
 			}
 
 			if err := router.Validate(val, query, body, path); err != nil {
-				w.WriteHeader(400)
-				_ = json.NewEncoder(w).Encode(err.Error())
+				writeValidationError(w, err)
 				return
 			}
 
+			if hook := router.DeprecationHook(); hook != nil {
+				for _, field := range crud.DeprecatedFields(val, query, body, path) {
+					hook(spec, field)
+				}
+			}
+
 			// Validate can strip values that are not valid, so we rewrite them
 			// after validation is complete. Can't use defer as in other adapters
 			// because next.ServeHTTP calls the next handler and defer hasn't
 			// run yet.
 			if rewriteBody {
-				data, _ := json.Marshal(body)
+				data, _ := bodyCodec.Encode(body)
 				_ = r.Body.Close()
 				r.Body = ioutil.NopCloser(bytes.NewReader(data))
 			}
@@ -127,7 +172,119 @@ func validateHandlerMiddleware(router *crud.Router, spec *crud.Spec) mux.Middlew
 				r.URL.RawQuery = query.Encode()
 			}
 
+			mode := router.ResponseMode()
+			accept := router.NegotiateAccept(r.Header.Get("Accept"))
+			if len(val.Responses) > 0 || accept != "application/json" {
+				rec := &responseRecorder{ResponseWriter: w, status: 200}
+				next.ServeHTTP(rec, r)
+				flushResponse(router, spec, val, mode, accept, rec, w)
+				return
+			}
+
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// mediaType strips any "; charset=..." style parameters from a
+// Content-Type/Accept value, defaulting to application/json when empty.
+func mediaType(contentType string) string {
+	if contentType == "" {
+		return "application/json"
+	}
+	for i := 0; i < len(contentType); i++ {
+		if contentType[i] == ';' {
+			return contentType[:i]
+		}
+	}
+	return contentType
+}
+
+// responseRecorder buffers a handler's response so it can be decoded
+// for response validation and/or re-encoded for the negotiated Accept
+// media type before it reaches the client.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	buf    bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(status int)         { rr.status = status }
+func (rr *responseRecorder) Write(data []byte) (int, error) { return rr.buf.Write(data) }
+
+// flushResponse decodes the handler's buffered (JSON) response body,
+// validates it against val.Responses when configured (which also
+// strips any WriteOnly properties), fires the DeprecationHook for any
+// Deprecated fields present, then writes it back out - re-encoded
+// whenever it was decoded at all.
+func flushResponse(router *crud.Router, spec *crud.Spec, val crud.Validate, mode option.ResponseMode, accept string, rec *responseRecorder, w http.ResponseWriter) {
+	var decoded interface{}
+	if rec.buf.Len() > 0 {
+		if err := json.Unmarshal(rec.buf.Bytes(), &decoded); err != nil {
+			w.WriteHeader(rec.status)
+			_, _ = w.Write(rec.buf.Bytes())
+			return
+		}
+	}
+
+	if len(val.Responses) > 0 {
+		if err := router.ValidateResponse(val.Responses, rec.status, decoded); err != nil && mode != option.ResponseModeOff {
+			switch mode {
+			case option.ResponseModeLog:
+				log.Printf("crud: invalid response for status %d: %v", rec.status, err)
+			case option.ResponseModeStrict:
+				log.Printf("crud: invalid response for status %d: %v", rec.status, err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		if hook := router.DeprecationHook(); hook != nil {
+			for _, field := range crud.DeprecatedResponseFields(val.Responses, rec.status, decoded) {
+				hook(spec, field)
+			}
+		}
+	}
+
+	if decoded == nil {
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(rec.buf.Bytes())
+		return
+	}
+
+	data, err := router.Codec(accept).Encode(decoded)
+	if err != nil {
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(rec.buf.Bytes())
+		return
+	}
+	// The re-encoded body's length almost never matches the original,
+	// so any Content-Length the handler set would be stale and corrupt
+	// the response on the wire.
+	w.Header().Del("Content-Length")
+	w.Header().Set("content-type", accept)
+	w.WriteHeader(rec.status)
+	_, _ = w.Write(data)
+}
+
+// validationErrorBody is the shape written to the client on a 400:
+// {"errors":[{"path":"...", "field":"...", "message":"..."}, ...]}.
+type validationErrorBody struct {
+	Errors []crud.ValidationError `json:"errors"`
+}
+
+func writeValidationError(w http.ResponseWriter, err error) {
+	var entries crud.ValidationErrors
+	switch v := err.(type) {
+	case crud.ValidationErrors:
+		entries = v
+	case crud.ValidationError:
+		entries = crud.ValidationErrors{v}
+	default:
+		entries = crud.ValidationErrors{{Message: err.Error()}}
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(400)
+	_ = json.NewEncoder(w).Encode(validationErrorBody{Errors: entries})
+}
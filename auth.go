@@ -0,0 +1,41 @@
+package crud
+
+import "net/http"
+
+// AuthAllowed reports whether active satisfies required. required is an
+// OR of AND groups - required[i] is one acceptable set of roles, and
+// active satisfies required if it is a superset of any required[i]. An
+// empty required imposes no requirement, so it's always satisfied.
+func AuthAllowed(required [][]string, active []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+
+	has := make(map[string]bool, len(active))
+	for _, role := range active {
+		has[role] = true
+	}
+
+	for _, group := range required {
+		fulfilled := true
+		for _, role := range group {
+			if !has[role] {
+				fulfilled = false
+				break
+			}
+		}
+		if fulfilled {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveRoles runs the router's option.AuthResolver against req, or
+// returns nil if none was configured.
+func (r *Router) ActiveRoles(req *http.Request) []string {
+	if r.opts.AuthResolver == nil {
+		return nil
+	}
+	return r.opts.AuthResolver(req)
+}
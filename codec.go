@@ -0,0 +1,52 @@
+package crud
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Codec encodes and decodes a single media type (e.g. application/json)
+// for use in request bodies and response bodies alike. Register
+// additional codecs with Router.RegisterCodec to support cbor, msgpack,
+// yaml, etc.
+type Codec interface {
+	Encode(v interface{}) ([]byte, error)
+	Decode(data []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(v interface{}) ([]byte, error)    { return json.Marshal(v) }
+func (jsonCodec) Decode(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// RegisterCodec adds (or replaces) the Codec used for mediaType when
+// negotiating request and response bodies. "application/json" is
+// registered by default.
+func (r *Router) RegisterCodec(mediaType string, codec Codec) {
+	r.codecs[mediaType] = codec
+}
+
+// Codec returns the Codec registered for mediaType, falling back to the
+// default application/json codec if none was registered for it.
+func (r *Router) Codec(mediaType string) Codec {
+	if codec, ok := r.codecs[mediaType]; ok {
+		return codec
+	}
+	return r.codecs["application/json"]
+}
+
+// NegotiateAccept picks a media type to encode a response as, given the
+// request's Accept header. It returns the first type in accept that has
+// a registered Codec, falling back to application/json.
+func (r *Router) NegotiateAccept(accept string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if mediaType == "" || mediaType == "*/*" {
+			continue
+		}
+		if _, ok := r.codecs[mediaType]; ok {
+			return mediaType
+		}
+	}
+	return "application/json"
+}
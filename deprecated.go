@@ -0,0 +1,65 @@
+package crud
+
+import "net/url"
+
+// DeprecatedFields reports the path of every Deprecated field present
+// in query, body, or path, for adapters to feed into
+// Router.DeprecationHook after a successful validation.
+func DeprecatedFields(v Validate, query url.Values, body interface{}, path map[string]string) []string {
+	var found []string
+
+	for name, field := range v.Path.properties {
+		if field.deprecated && path[name] != "" {
+			found = append(found, "path/"+name)
+		}
+	}
+	for name, field := range v.Query.properties {
+		if field.deprecated {
+			if raw, ok := query[name]; ok && len(raw) > 0 && raw[0] != "" {
+				found = append(found, "query/"+name)
+			}
+		}
+	}
+	collectDeprecatedBody(v.Body, body, "", &found)
+
+	return found
+}
+
+// DeprecatedResponseFields reports the path of every Deprecated field
+// present in a response body, for adapters to feed into
+// Router.DeprecationHook after a successful response validation. It
+// resolves responses[status] the same way Router.ValidateResponse
+// does, falling back to the entry at key 0.
+func DeprecatedResponseFields(responses map[int]Field, status int, body interface{}) []string {
+	field, ok := responses[status]
+	if !ok {
+		field, ok = responses[0]
+	}
+	if !ok || !field.Initialized() {
+		return nil
+	}
+
+	var found []string
+	collectDeprecatedBody(field, body, "", &found)
+	return found
+}
+
+func collectDeprecatedBody(field Field, value interface{}, path string, found *[]string) {
+	if field.kind != KindObject {
+		return
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for name, sub := range field.properties {
+		child, present := obj[name]
+		if !present || child == nil {
+			continue
+		}
+		if sub.deprecated {
+			*found = append(*found, path+"/"+name)
+		}
+		collectDeprecatedBody(sub, child, path+"/"+name, found)
+	}
+}
@@ -0,0 +1,17 @@
+package crud
+
+import "errors"
+
+// Sentinel errors returned (optionally wrapped with field context) by
+// Router.Validate. Callers can test for a specific failure with
+// errors.Is.
+var (
+	errRequired     = errors.New("field is required")
+	errWrongType    = errors.New("wrong type")
+	errMinimum      = errors.New("value below minimum")
+	errMaximum      = errors.New("value above maximum")
+	errEnumNotFound = errors.New("value not found in enum")
+	errUnknown      = errors.New("unknown field")
+	errFormat       = errors.New("value does not match format")
+	errReadOnly     = errors.New("field is read-only")
+)
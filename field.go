@@ -0,0 +1,138 @@
+package crud
+
+import "regexp"
+
+// Field describes the validation schema for a single value - a query
+// parameter, a path parameter, or a JSON body (or one of its
+// properties). Fields are built with the package-level constructors
+// (String, Number, Integer, Boolean, Array, Object) and refined with
+// chainable modifiers such as Required, Default, Min, Max, and Enum.
+//
+// The zero value of Field is a valid, uninitialized Field; Initialized
+// reports whether one of the constructors produced it.
+type Field struct {
+	init bool
+	kind Kind
+
+	required   bool
+	hasDefault bool
+	def        interface{}
+	enum       []interface{}
+	hasMin     bool
+	min        float64
+	hasMax     bool
+	max        float64
+	items      *Field
+	properties map[string]Field
+	format     string
+	pattern    string
+	patternRe  *regexp.Regexp
+	readOnly   bool
+	writeOnly  bool
+	deprecated bool
+
+	stripUnknown *bool
+	allowUnknown *bool
+}
+
+func newField(kind Kind) Field {
+	return Field{init: true, kind: kind}
+}
+
+// String builds a Field validating a string value.
+func String() Field { return newField(KindString) }
+
+// Number builds a Field validating any JSON number.
+func Number() Field { return newField(KindNumber) }
+
+// Integer builds a Field validating a whole-number JSON number.
+func Integer() Field { return newField(KindInteger) }
+
+// Boolean builds a Field validating a JSON boolean.
+func Boolean() Field { return newField(KindBoolean) }
+
+// Array builds a Field validating a JSON array. Use Items to constrain
+// the element type.
+func Array() Field { return newField(KindArray) }
+
+// Object builds a Field validating a JSON object with the given named
+// properties.
+func Object(properties map[string]Field) Field {
+	f := newField(KindObject)
+	f.properties = properties
+	return f
+}
+
+// Required marks the field as mandatory; absent or empty values fail
+// validation with errRequired.
+func (f Field) Required() Field { f.required = true; return f }
+
+// Default supplies a value to use when the field is absent.
+func (f Field) Default(value interface{}) Field {
+	f.hasDefault = true
+	f.def = value
+	return f
+}
+
+// Enum restricts the field to one of the given values.
+func (f Field) Enum(values ...interface{}) Field { f.enum = values; return f }
+
+// Min sets a lower bound: a numeric minimum for Number/Integer, or a
+// minimum element count for Array.
+func (f Field) Min(n float64) Field { f.hasMin = true; f.min = n; return f }
+
+// Max sets an upper bound: a numeric maximum for Number/Integer, or a
+// maximum element count for Array.
+func (f Field) Max(n float64) Field { f.hasMax = true; f.max = n; return f }
+
+// Items sets the schema each element of an Array field must satisfy.
+func (f Field) Items(item Field) Field { f.items = &item; return f }
+
+// Format names a registered string format (e.g. "email", "uuid") a
+// string field's value must satisfy. See option.RegisterFormat to add
+// your own.
+func (f Field) Format(name string) Field { f.format = name; return f }
+
+// Pattern requires a string field's value to match a regular
+// expression. The pattern is compiled once, here, rather than on every
+// validated request; an invalid pattern is kept and causes every value
+// to fail validation (checkFormat surfaces it the same as a mismatch).
+func (f Field) Pattern(pattern string) Field {
+	f.pattern = pattern
+	f.patternRe, _ = regexp.Compile(pattern)
+	return f
+}
+
+// ReadOnly marks a property as request-server-generated: present
+// request bodies are rejected (or stripped under StripUnknown), but the
+// property is still validated as part of a response. Combined with
+// Required, the property is treated as not required for requests but
+// still required for responses.
+func (f Field) ReadOnly() Field { f.readOnly = true; return f }
+
+// WriteOnly marks a property as client-to-server-only: it validates
+// normally in requests, but is stripped from a response before it's
+// sent.
+func (f Field) WriteOnly() Field { f.writeOnly = true; return f }
+
+// Deprecated marks a property as deprecated. It still validates
+// normally; option.DeprecationHook is invoked whenever a request or
+// response supplies it, and generated schemas mark it
+// "deprecated": true.
+func (f Field) Deprecated() Field { f.deprecated = true; return f }
+
+// Strip overrides the router's StripUnknown option for this object (and
+// its descendants, unless they set their own override).
+func (f Field) Strip(strip bool) Field { f.stripUnknown = &strip; return f }
+
+// Unknown overrides the router's AllowUnknown option for this object
+// (and its descendants, unless they set their own override).
+func (f Field) Unknown(allow bool) Field { f.allowUnknown = &allow; return f }
+
+// Initialized reports whether f was produced by one of the Field
+// constructors, as opposed to being a zero value left unset in a
+// Validate literal.
+func (f Field) Initialized() bool { return f.init }
+
+// Kind reports the Field's Kind.
+func (f Field) Kind() Kind { return f.kind }
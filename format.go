@@ -0,0 +1,127 @@
+package crud
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// defaultFormats are the string formats every Router recognizes out of
+// the box, matching the formats kin-openapi surfaces for OpenAPI
+// validation. Use option.RegisterFormat to add or override one.
+var defaultFormats = map[string]func(string) error{
+	"email":     validateEmailFormat,
+	"uuid":      validateUUIDFormat,
+	"ipv4":      validateIPv4Format,
+	"ipv6":      validateIPv6Format,
+	"uri":       validateURIFormat,
+	"hostname":  validateHostnameFormat,
+	"date":      validateDateFormat,
+	"date-time": validateDateTimeFormat,
+	"byte":      validateByteFormat,
+}
+
+var (
+	emailPattern    = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	ipv4Pattern     = regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}$`)
+	hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,62}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,62}[a-zA-Z0-9])?)*$`)
+)
+
+func validateEmailFormat(value string) error {
+	if !emailPattern.MatchString(value) {
+		return fmt.Errorf("%q is not a valid email address", value)
+	}
+	return nil
+}
+
+func validateUUIDFormat(value string) error {
+	if !uuidPattern.MatchString(value) {
+		return fmt.Errorf("%q is not a valid uuid", value)
+	}
+	return nil
+}
+
+// validateIPv4Format requires dotted-decimal notation, so it correctly
+// rejects an IPv4-mapped IPv6 address like "::ffff:192.0.2.1".
+func validateIPv4Format(value string) error {
+	if !ipv4Pattern.MatchString(value) || net.ParseIP(value) == nil {
+		return fmt.Errorf("%q is not a valid ipv4 address", value)
+	}
+	return nil
+}
+
+// validateIPv6Format accepts anything net.ParseIP parses that contains
+// a colon, so an IPv4-mapped address such as "::ffff:192.0.2.1" is
+// valid ipv6 even though it embeds dotted-decimal notation.
+func validateIPv6Format(value string) error {
+	if !strings.Contains(value, ":") || net.ParseIP(value) == nil {
+		return fmt.Errorf("%q is not a valid ipv6 address", value)
+	}
+	return nil
+}
+
+func validateURIFormat(value string) error {
+	u, err := url.ParseRequestURI(value)
+	if err != nil || u.Scheme == "" {
+		return fmt.Errorf("%q is not a valid uri", value)
+	}
+	return nil
+}
+
+func validateHostnameFormat(value string) error {
+	if len(value) > 253 || !hostnamePattern.MatchString(value) {
+		return fmt.Errorf("%q is not a valid hostname", value)
+	}
+	return nil
+}
+
+// validateDateFormat requires a date-only value and rejects a full
+// RFC3339 timestamp.
+func validateDateFormat(value string) error {
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		return fmt.Errorf("%q is not a valid date", value)
+	}
+	return nil
+}
+
+// validateDateTimeFormat requires RFC3339 and rejects a date-only
+// value.
+func validateDateTimeFormat(value string) error {
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return fmt.Errorf("%q is not a valid date-time", value)
+	}
+	return nil
+}
+
+func validateByteFormat(value string) error {
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+		return fmt.Errorf("%q is not valid base64", value)
+	}
+	return nil
+}
+
+// checkFormat validates value against field's registered format and/or
+// regular expression pattern, appending a failure to entries for each
+// that doesn't hold.
+func (r *Router) checkFormat(field Field, value, path string, entries *[]ValidationError) {
+	if field.pattern != "" {
+		if field.patternRe == nil || !field.patternRe.MatchString(value) {
+			*entries = append(*entries, newValidationError(path, "pattern", errWrongType, value))
+		}
+	}
+	if field.format == "" {
+		return
+	}
+	fn, ok := r.formats[field.format]
+	if !ok {
+		return
+	}
+	if err := fn(value); err != nil {
+		*entries = append(*entries, newValidationError(path, "format", fmt.Errorf("%w: %s: %v", errFormat, field.format, err), value))
+	}
+}
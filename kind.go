@@ -0,0 +1,14 @@
+package crud
+
+// Kind identifies the shape a Field validates against.
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindNumber
+	KindInteger
+	KindBoolean
+	KindArray
+	KindObject
+	KindFile
+)
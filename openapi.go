@@ -0,0 +1,180 @@
+package crud
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// OpenAPI is the root OpenAPI 3.0 document served at /openapi.json and
+// /openapi.yaml when option.SpecVersion(V3) or option.SpecVersion(Both)
+// is set.
+type OpenAPI struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       SwaggerInfo                `json:"info"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components,omitempty"`
+}
+
+// OpenAPIPathItem maps HTTP methods (lowercase) to the Operation
+// mounted at a path.
+type OpenAPIPathItem map[string]OpenAPIOperation
+
+// OpenAPIOperation describes a single method on a path.
+type OpenAPIOperation struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+}
+
+// OpenAPIParameter is a query or path parameter.
+type OpenAPIParameter struct {
+	Name     string                 `json:"name"`
+	In       string                 `json:"in"`
+	Required bool                   `json:"required,omitempty"`
+	Schema   map[string]interface{} `json:"schema"`
+}
+
+// OpenAPIRequestBody describes a request body, keyed by media type so a
+// single handler can serve multiple representations.
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required,omitempty"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIMediaType pairs a schema with the media type it's served as.
+type OpenAPIMediaType struct {
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// OpenAPIResponse describes one entry of an operation's responses map.
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIComponents holds the deduplicated schemas and security schemes
+// referenced from Paths.
+type OpenAPIComponents struct {
+	Schemas         map[string]map[string]interface{} `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme         `json:"securitySchemes,omitempty"`
+}
+
+func (r *Router) openAPI() *OpenAPI {
+	doc := &OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    SwaggerInfo{Title: r.title, Version: r.version},
+		Paths:   map[string]OpenAPIPathItem{},
+	}
+	schemas := map[string]map[string]interface{}{}
+
+	for _, spec := range r.specs {
+		item, ok := doc.Paths[spec.Path]
+		if !ok {
+			item = OpenAPIPathItem{}
+			doc.Paths[spec.Path] = item
+		}
+
+		op := OpenAPIOperation{
+			Summary:     spec.Summary,
+			Description: spec.Description,
+			Parameters:  openAPIParameters(spec.Validate),
+			Responses:   r.openAPIResponses(spec.Validate, schemas),
+			Security:    authSecurity(spec.Auth),
+		}
+		if spec.Validate.Body.Initialized() {
+			op.RequestBody = &OpenAPIRequestBody{
+				Required: true,
+				Content:  r.mediaTypeContent(componentRef(spec.Validate.Body, schemas)),
+			}
+		}
+
+		item[strings.ToLower(spec.Method)] = op
+
+		if len(spec.Auth) > 0 && doc.Components.SecuritySchemes == nil {
+			doc.Components.SecuritySchemes = map[string]SecurityScheme{
+				authSecurityScheme: {Type: "apiKey", Name: "Authorization", In: "header"},
+			}
+		}
+	}
+
+	doc.Components.Schemas = schemas
+	return doc
+}
+
+func openAPIParameters(v Validate) []OpenAPIParameter {
+	var params []OpenAPIParameter
+	params = append(params, namedOpenAPIParameters("query", v.Query)...)
+	params = append(params, namedOpenAPIParameters("path", v.Path)...)
+	return params
+}
+
+func namedOpenAPIParameters(in string, obj Field) []OpenAPIParameter {
+	if !obj.Initialized() {
+		return nil
+	}
+
+	var params []OpenAPIParameter
+	for name, field := range obj.properties {
+		params = append(params, OpenAPIParameter{
+			Name:     name,
+			In:       in,
+			Required: field.required,
+			Schema:   fieldSchema(field, nil),
+		})
+	}
+	return params
+}
+
+func (r *Router) openAPIResponses(v Validate, schemas map[string]map[string]interface{}) map[string]OpenAPIResponse {
+	responses := map[string]OpenAPIResponse{}
+	if len(v.Responses) == 0 {
+		responses["200"] = OpenAPIResponse{Description: "OK"}
+		return responses
+	}
+
+	for status, field := range v.Responses {
+		key := "default"
+		if status != 0 {
+			key = strconv.Itoa(status)
+		}
+		responses[key] = OpenAPIResponse{
+			Description: "OK",
+			Content:     r.mediaTypeContent(componentRef(field, schemas)),
+		}
+	}
+	return responses
+}
+
+func (r *Router) mediaTypeContent(schema map[string]interface{}) map[string]OpenAPIMediaType {
+	content := make(map[string]OpenAPIMediaType, len(r.codecs))
+	for mediaType := range r.codecs {
+		content[mediaType] = OpenAPIMediaType{Schema: schema}
+	}
+	return content
+}
+
+// componentRef renders field as a schema, registering Object shapes
+// under components/schemas (deduplicated by a structural hash of the
+// rendered schema) and returning a $ref to it. Non-object fields are
+// returned inline - there's nothing to gain from refing a scalar.
+func componentRef(field Field, schemas map[string]map[string]interface{}) map[string]interface{} {
+	schema := fieldSchema(field, schemas)
+	if field.kind != KindObject {
+		return schema
+	}
+
+	data, _ := json.Marshal(schema)
+	sum := sha256.Sum256(data)
+	name := "Object" + hex.EncodeToString(sum[:])[:12]
+
+	if _, ok := schemas[name]; !ok {
+		schemas[name] = schema
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
@@ -0,0 +1,117 @@
+// Package option holds the functional options accepted by
+// crud.NewRouter.
+package option
+
+import "net/http"
+
+// Options holds the resolved router-wide defaults. Individual Fields
+// (via Field.Strip/Field.Unknown) can override StripUnknown and
+// AllowUnknown for themselves and their descendants.
+type Options struct {
+	StripUnknown      bool
+	AllowUnknown      bool
+	AggregateErrors   bool
+	ValidateResponses ResponseMode
+	AuthResolver      func(*http.Request) []string
+	Formats           map[string]func(string) error
+
+	// DeprecationHook is invoked whenever a request or response supplies
+	// a Deprecated field. It's typed as func(interface{}, string) here to
+	// avoid option importing crud; Router.DeprecationHook exposes the
+	// typed func(*crud.Spec, string) form adapters should use.
+	DeprecationHook func(spec interface{}, field string)
+
+	SpecVersion Version
+}
+
+// Version selects which generated API document(s) a Router serves.
+type Version int
+
+const (
+	// V2 serves only the Swagger 2.0 document, at /swagger.json. This is
+	// the default, for backwards compatibility.
+	V2 Version = iota
+	// V3 serves only the OpenAPI 3.0 document, at /openapi.json and
+	// /openapi.yaml.
+	V3
+	// Both serves the Swagger 2.0 and OpenAPI 3.0 documents side by
+	// side.
+	Both
+)
+
+// ResponseMode controls how a failed response-body validation is
+// handled.
+type ResponseMode int
+
+const (
+	// ResponseModeOff skips response validation entirely (the default).
+	ResponseModeOff ResponseMode = iota
+	// ResponseModeLog validates responses and logs failures, without
+	// altering the response sent to the client.
+	ResponseModeLog
+	// ResponseModeStrict validates responses and replaces a failing
+	// response with a 500 before anything is written to the client.
+	ResponseModeStrict
+)
+
+// Option mutates the router's default Options.
+type Option func(*Options)
+
+// StripUnknown sets whether unknown object/query properties are
+// silently removed during validation. Defaults to false.
+func StripUnknown(strip bool) Option {
+	return func(o *Options) { o.StripUnknown = strip }
+}
+
+// AllowUnknown sets whether unknown object/query properties are
+// tolerated at all. When false, an unknown property that isn't stripped
+// fails validation with errUnknown. Defaults to true.
+func AllowUnknown(allow bool) Option {
+	return func(o *Options) { o.AllowUnknown = allow }
+}
+
+// AggregateErrors sets whether Router.Validate walks the entire request
+// and returns every failure as a crud.ValidationErrors, instead of the
+// default fail-fast behavior of reporting only the first failure found.
+func AggregateErrors(aggregate bool) Option {
+	return func(o *Options) { o.AggregateErrors = aggregate }
+}
+
+// ValidateResponses sets whether and how response bodies are validated
+// against a Spec's Validate.Responses schemas. Defaults to
+// ResponseModeOff.
+func ValidateResponses(mode ResponseMode) Option {
+	return func(o *Options) { o.ValidateResponses = mode }
+}
+
+// AuthResolver registers the function used to determine a request's
+// active roles, for checking against a Spec's Auth requirement.
+func AuthResolver(fn func(*http.Request) []string) Option {
+	return func(o *Options) { o.AuthResolver = fn }
+}
+
+// RegisterFormat adds (or replaces) the validator used for a
+// Field.Format(name) string format. See crud's default formats (email,
+// uuid, ipv4, ipv6, uri, hostname, date, date-time, byte) for the set
+// registered out of the box.
+func RegisterFormat(name string, fn func(string) error) Option {
+	return func(o *Options) {
+		if o.Formats == nil {
+			o.Formats = map[string]func(string) error{}
+		}
+		o.Formats[name] = fn
+	}
+}
+
+// DeprecationHook registers a callback fired (with the *crud.Spec of
+// the matched route and the field's path) whenever a request or
+// response supplies a Deprecated field - useful for metrics or logging.
+func DeprecationHook(fn func(spec interface{}, field string)) Option {
+	return func(o *Options) { o.DeprecationHook = fn }
+}
+
+// SpecVersion selects which generated API document(s) Router.Serve
+// builds and hands to the adapter. Defaults to V2.
+func SpecVersion(version Version) Option {
+	return func(o *Options) { o.SpecVersion = version }
+}
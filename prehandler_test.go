@@ -14,7 +14,7 @@ func (t *TestAdapter) Install(router *Router, spec *Spec) error {
 	return nil
 }
 
-func (t *TestAdapter) Serve(swagger *Swagger, addr string) error {
+func (t *TestAdapter) Serve(swagger *Swagger, openapi *OpenAPI, addr string) error {
 	return nil
 }
 
@@ -226,6 +226,139 @@ func TestQueryValidation(t *testing.T) {
 	}
 }
 
+func TestFormatValidation(t *testing.T) {
+	r := NewRouter("", "", &TestAdapter{})
+
+	tests := []struct {
+		Schema   map[string]Field
+		Input    string
+		Expected error
+	}{
+		{
+			Schema:   map[string]Field{"testquery": String().Format("email")},
+			Input:    "testquery=user@example.com",
+			Expected: nil,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("email")},
+			Input:    "testquery=not-an-email",
+			Expected: errFormat,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("email")},
+			Input:    "",
+			Expected: nil,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("uuid")},
+			Input:    "testquery=3b241101-e2bb-4255-8caf-4136c566a962",
+			Expected: nil,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("uuid")},
+			Input:    "testquery=not-a-uuid",
+			Expected: errFormat,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("ipv4")},
+			Input:    "testquery=192.0.2.1",
+			Expected: nil,
+		},
+		{
+			// IPv4-mapped IPv6 must not pass the stricter ipv4 format.
+			Schema:   map[string]Field{"testquery": String().Format("ipv4")},
+			Input:    "testquery=::ffff:192.0.2.1",
+			Expected: errFormat,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("ipv6")},
+			Input:    "testquery=2001:db8::1",
+			Expected: nil,
+		},
+		{
+			// IPv4-mapped IPv6 is valid ipv6.
+			Schema:   map[string]Field{"testquery": String().Format("ipv6")},
+			Input:    "testquery=::ffff:192.0.2.1",
+			Expected: nil,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("date")},
+			Input:    "testquery=2021-01-02",
+			Expected: nil,
+		},
+		{
+			// date must not accept a full RFC3339 timestamp.
+			Schema:   map[string]Field{"testquery": String().Format("date")},
+			Input:    "testquery=2021-01-02T15:04:05Z",
+			Expected: errFormat,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("date-time")},
+			Input:    "testquery=2021-01-02T15:04:05Z",
+			Expected: nil,
+		},
+		{
+			// date-time must not accept a date-only value.
+			Schema:   map[string]Field{"testquery": String().Format("date-time")},
+			Input:    "testquery=2021-01-02",
+			Expected: errFormat,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("uri")},
+			Input:    "testquery=https%3A%2F%2Fexample.com%2Fpath",
+			Expected: nil,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("uri")},
+			Input:    "testquery=not a uri",
+			Expected: errFormat,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("hostname")},
+			Input:    "testquery=example.com",
+			Expected: nil,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("hostname")},
+			Input:    "testquery=not_a_hostname!",
+			Expected: errFormat,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("byte")},
+			Input:    "testquery=aGVsbG8=",
+			Expected: nil,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Format("byte")},
+			Input:    "testquery=not base64!",
+			Expected: errFormat,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Pattern("^[a-z]+$")},
+			Input:    "testquery=abc",
+			Expected: nil,
+		},
+		{
+			Schema:   map[string]Field{"testquery": String().Pattern("^[a-z]+$")},
+			Input:    "testquery=ABC",
+			Expected: errWrongType,
+		},
+	}
+
+	for i, test := range tests {
+		query, err := url.ParseQuery(test.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = r.Validate(Validate{Query: Object(test.Schema)}, query, nil, nil)
+
+		if !errors.Is(err, test.Expected) {
+			t.Errorf("%v: expected '%v' got '%v'. input: '%v'. schema: '%v'", i, test.Expected, err, test.Input, test.Schema)
+		}
+	}
+}
+
 func TestQueryDefaults(t *testing.T) {
 	r := NewRouter("", "", &TestAdapter{})
 
@@ -535,6 +668,113 @@ func TestBodyErrorUnknown(t *testing.T) {
 	}
 }
 
+func TestBodyReadOnly(t *testing.T) {
+	r := NewRouter("", "", &TestAdapter{})
+
+	schema := Object(map[string]Field{
+		"id": Integer().ReadOnly().Required(),
+	})
+
+	// Request: supplying a readOnly field is rejected.
+	var input interface{}
+	if err := json.Unmarshal([]byte(`{"id":1}`), &input); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Validate(Validate{Body: schema}, nil, input, nil); !errors.Is(err, errReadOnly) {
+		t.Errorf("expected errReadOnly got %v", err)
+	}
+
+	// Request: omitting a readOnly+Required field is fine, even though
+	// it's Required.
+	if err := json.Unmarshal([]byte(`{}`), &input); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Validate(Validate{Body: schema}, nil, input, nil); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// Response: the readOnly+Required field is required.
+	if err := json.Unmarshal([]byte(`{}`), &input); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ValidateResponse(map[int]Field{200: schema}, 200, input); !errors.Is(err, errRequired) {
+		t.Errorf("expected errRequired got %v", err)
+	}
+}
+
+func TestBodyReadOnly_StripUnknown(t *testing.T) {
+	r := NewRouter("", "", &TestAdapter{}, option.StripUnknown(true))
+
+	schema := Object(map[string]Field{
+		"id": Integer().ReadOnly(),
+	})
+
+	var input interface{}
+	if err := json.Unmarshal([]byte(`{"id":1}`), &input); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Validate(Validate{Body: schema}, nil, input, nil); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, ok := input.(map[string]interface{})["id"]; ok {
+		t.Error("expected readOnly field to be stripped")
+	}
+}
+
+func TestResponseWriteOnly(t *testing.T) {
+	r := NewRouter("", "", &TestAdapter{})
+
+	schema := Object(map[string]Field{
+		"password": String().WriteOnly(),
+		"name":     String(),
+	})
+
+	var input interface{}
+	if err := json.Unmarshal([]byte(`{"password":"secret","name":"ok"}`), &input); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ValidateResponse(map[int]Field{200: schema}, 200, input); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, ok := input.(map[string]interface{})["password"]; ok {
+		t.Error("expected writeOnly field to be stripped from the response")
+	}
+	if _, ok := input.(map[string]interface{})["name"]; !ok {
+		t.Error("expected non-writeOnly field to survive")
+	}
+}
+
+func TestResponseWriteOnly_Required(t *testing.T) {
+	r := NewRouter("", "", &TestAdapter{})
+
+	// A field that's both WriteOnly and Required makes sense on a
+	// request body (required to submit, never echoed back) - it must
+	// not be flagged missing when validating the response that omits it.
+	schema := Object(map[string]Field{
+		"password": String().WriteOnly().Required(),
+		"name":     String(),
+	})
+
+	var present interface{}
+	if err := json.Unmarshal([]byte(`{"password":"secret","name":"ok"}`), &present); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ValidateResponse(map[int]Field{200: schema}, 200, present); err != nil {
+		t.Errorf("unexpected error with password present: %v", err)
+	}
+	if _, ok := present.(map[string]interface{})["password"]; ok {
+		t.Error("expected writeOnly field to be stripped from the response")
+	}
+
+	var absent interface{}
+	if err := json.Unmarshal([]byte(`{"name":"ok"}`), &absent); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ValidateResponse(map[int]Field{200: schema}, 200, absent); err != nil {
+		t.Errorf("expected writeOnly+required field to not be flagged missing from the response, got %v", err)
+	}
+}
+
 func TestPathValidation(t *testing.T) {
 	r := NewRouter("", "", &TestAdapter{})
 
@@ -682,6 +922,51 @@ func TestStrip_Query(t *testing.T) {
 	}
 }
 
+func TestAuthAllowed(t *testing.T) {
+	tests := []struct {
+		Required [][]string
+		Active   []string
+		Expected bool
+	}{
+		{
+			Required: nil,
+			Active:   nil,
+			Expected: true,
+		},
+		{
+			Required: [][]string{{"admin"}},
+			Active:   []string{"admin"},
+			Expected: true,
+		},
+		{
+			Required: [][]string{{"editor", "owner"}},
+			Active:   []string{"editor"},
+			Expected: false,
+		},
+		{
+			Required: [][]string{{"editor", "owner"}},
+			Active:   []string{"editor", "owner"},
+			Expected: true,
+		},
+		{
+			Required: [][]string{{"admin"}, {"editor", "owner"}},
+			Active:   []string{"owner", "editor"},
+			Expected: true,
+		},
+		{
+			Required: [][]string{{"admin"}, {"editor", "owner"}},
+			Active:   nil,
+			Expected: false,
+		},
+	}
+
+	for i, test := range tests {
+		if got := AuthAllowed(test.Required, test.Active); got != test.Expected {
+			t.Errorf("%v: expected '%v' got '%v'. required: '%v'. active: '%v'", i, test.Expected, got, test.Required, test.Active)
+		}
+	}
+}
+
 func Test_BodyValidateRequiredAutomatically(t *testing.T) {
 	r := NewRouter("", "", &TestAdapter{}, option.AllowUnknown(false))
 
@@ -691,3 +976,52 @@ func Test_BodyValidateRequiredAutomatically(t *testing.T) {
 		t.Error("Expected errRequired got", err)
 	}
 }
+
+func TestAggregateErrors(t *testing.T) {
+	r := NewRouter("", "", &TestAdapter{}, option.AggregateErrors(true))
+
+	v := Validate{
+		Path: Object(map[string]Field{
+			"id": Integer().Required(),
+		}),
+		Query: Object(map[string]Field{
+			"testquery": String().Required(),
+		}),
+		Body: Object(map[string]Field{
+			"complex1": Object(map[string]Field{
+				"array": Array().Required().Items(Object(map[string]Field{
+					"id": Number().Required(),
+				})),
+			}).Required(),
+		}),
+	}
+
+	var body interface{}
+	if err := json.Unmarshal([]byte(`{"complex1":{"array":[{}]}}`), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	err := r.Validate(v, url.Values{}, body, map[string]string{})
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	expected := []string{"path/id", "query/testquery", "/complex1/array/0/id"}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, path := range expected {
+		if errs[i].Path != path {
+			t.Errorf("entry %d: expected path %q got %q", i, path, errs[i].Path)
+		}
+		if !errors.Is(errs[i], errRequired) {
+			t.Errorf("entry %d: expected errRequired, got %v", i, errs[i].Unwrap())
+		}
+	}
+
+	if !errors.Is(err, errRequired) {
+		t.Error("expected errors.Is to see through ValidationErrors to errRequired")
+	}
+}
@@ -0,0 +1,89 @@
+package crud
+
+import "github.com/swillbanks-firstorion/crud/option"
+
+// Router collects Specs, validates requests against them, and delegates
+// wiring and serving to an Adapter.
+type Router struct {
+	title   string
+	version string
+	adapter Adapter
+	specs   []Spec
+
+	opts    option.Options
+	codecs  map[string]Codec
+	formats map[string]func(string) error
+}
+
+// NewRouter creates a Router that installs its Specs onto adapter and
+// serves documentation describing title/version.
+func NewRouter(title, version string, adapter Adapter, opts ...option.Option) *Router {
+	o := option.Options{AllowUnknown: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	formats := make(map[string]func(string) error, len(defaultFormats))
+	for name, fn := range defaultFormats {
+		formats[name] = fn
+	}
+	for name, fn := range o.Formats {
+		formats[name] = fn
+	}
+
+	return &Router{
+		title:   title,
+		version: version,
+		adapter: adapter,
+		opts:    o,
+		codecs:  map[string]Codec{"application/json": jsonCodec{}},
+		formats: formats,
+	}
+}
+
+// Add registers specs with the router and installs each of them onto
+// the adapter.
+func (r *Router) Add(specs ...Spec) error {
+	for _, spec := range specs {
+		spec := spec
+		if err := r.adapter.Install(r, &spec); err != nil {
+			return err
+		}
+		r.specs = append(r.specs, spec)
+	}
+	return nil
+}
+
+// DeprecationHook returns the router's configured
+// option.DeprecationHook, typed against *Spec, or nil if none was
+// registered.
+func (r *Router) DeprecationHook() func(spec *Spec, field string) {
+	if r.opts.DeprecationHook == nil {
+		return nil
+	}
+	return func(spec *Spec, field string) { r.opts.DeprecationHook(spec, field) }
+}
+
+// ResponseMode reports the router's configured option.ValidateResponses
+// mode, for adapters deciding whether to intercept response bodies.
+func (r *Router) ResponseMode() option.ResponseMode {
+	return r.opts.ValidateResponses
+}
+
+// Serve builds the Swagger 2.0 and/or OpenAPI 3.0 documents (per
+// option.SpecVersion) for every registered Spec and hands them to the
+// adapter, which mounts them alongside the routes already installed and
+// blocks serving traffic on addr.
+func (r *Router) Serve(addr string) error {
+	var swagger *Swagger
+	var openapi *OpenAPI
+
+	if r.opts.SpecVersion == option.V2 || r.opts.SpecVersion == option.Both {
+		swagger = r.swagger()
+	}
+	if r.opts.SpecVersion == option.V3 || r.opts.SpecVersion == option.Both {
+		openapi = r.openAPI()
+	}
+
+	return r.adapter.Serve(swagger, openapi, addr)
+}
@@ -0,0 +1,98 @@
+package crud
+
+import "sort"
+
+// fieldSchema renders f as a JSON Schema-shaped map, used wherever the
+// generated Swagger/OpenAPI documents need to describe a Field: request
+// parameters, the body schema, and (later) response schemas. schemas is
+// nil for Swagger 2.0 (which gets every Object inlined); for OpenAPI 3.0
+// it's the in-progress components/schemas map, and any nested Object
+// property or array item is hoisted into it via componentRef instead of
+// being inlined, the same way the top-level body/response schema is.
+func fieldSchema(f Field, schemas map[string]map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{}
+
+	switch f.kind {
+	case KindString:
+		schema["type"] = "string"
+	case KindNumber:
+		schema["type"] = "number"
+	case KindInteger:
+		schema["type"] = "integer"
+	case KindBoolean:
+		schema["type"] = "boolean"
+	case KindArray:
+		schema["type"] = "array"
+		if f.items != nil {
+			schema["items"] = schemaOrRef(*f.items, schemas)
+		}
+	case KindObject:
+		schema["type"] = "object"
+		properties := map[string]interface{}{}
+		var required []string
+		for name, sub := range f.properties {
+			properties[name] = schemaOrRef(sub, schemas)
+			if sub.required {
+				required = append(required, name)
+			}
+		}
+		schema["properties"] = properties
+		if len(required) > 0 {
+			// f.properties is a map, so iteration order (and thus the order
+			// required is built in) varies across calls; sort so that two
+			// calls against the same Field hash identically in componentRef.
+			sort.Strings(required)
+			schema["required"] = required
+		}
+	}
+
+	if f.hasMin {
+		if f.kind == KindArray {
+			schema["minItems"] = f.min
+		} else {
+			schema["minimum"] = f.min
+		}
+	}
+	if f.hasMax {
+		if f.kind == KindArray {
+			schema["maxItems"] = f.max
+		} else {
+			schema["maximum"] = f.max
+		}
+	}
+	if len(f.enum) > 0 {
+		schema["enum"] = f.enum
+	}
+	if f.hasDefault {
+		schema["default"] = f.def
+	}
+	if f.format != "" {
+		schema["format"] = f.format
+	}
+	if f.pattern != "" {
+		schema["pattern"] = f.pattern
+	}
+	if f.readOnly {
+		schema["readOnly"] = true
+	}
+	if f.writeOnly {
+		schema["writeOnly"] = true
+	}
+	if f.deprecated {
+		schema["deprecated"] = true
+	}
+
+	return schema
+}
+
+// schemaOrRef renders sub inline, unless schemas is non-nil and sub is
+// an Object - in which case it's hoisted into components/schemas via
+// componentRef and returned as a $ref, so a shared nested shape (e.g.
+// an "Address" object reused across several request bodies) is
+// deduplicated the same way a top-level body/response schema is.
+func schemaOrRef(sub Field, schemas map[string]map[string]interface{}) map[string]interface{} {
+	if schemas != nil && sub.kind == KindObject {
+		return componentRef(sub, schemas)
+	}
+	return fieldSchema(sub, schemas)
+}
@@ -0,0 +1,91 @@
+package crud
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComponentRefDedup(t *testing.T) {
+	r := NewRouter("t", "1.0", &TestAdapter{})
+
+	addr := Object(map[string]Field{
+		"city": String().Required(),
+	})
+
+	if err := r.Add(
+		Spec{
+			Method:   "POST",
+			Path:     "/a",
+			Validate: Validate{Body: addr},
+		},
+		Spec{
+			Method:   "POST",
+			Path:     "/b",
+			Validate: Validate{Body: addr},
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := r.openAPI()
+	if got := len(doc.Components.Schemas); got != 1 {
+		t.Errorf("expected 1 deduplicated components/schemas entry, got %d: %v", got, doc.Components.Schemas)
+	}
+}
+
+func TestComponentRefDedup_Nested(t *testing.T) {
+	r := NewRouter("t", "1.0", &TestAdapter{})
+
+	addr := Object(map[string]Field{
+		"city": String().Required(),
+	})
+
+	if err := r.Add(
+		Spec{
+			Method: "POST",
+			Path:   "/a",
+			Validate: Validate{Body: Object(map[string]Field{
+				"home": addr,
+			})},
+		},
+		Spec{
+			Method: "POST",
+			Path:   "/b",
+			Validate: Validate{Body: Object(map[string]Field{
+				"work": addr,
+			})},
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := r.openAPI()
+	// Each body is itself a distinct Object, so it gets its own
+	// components/schemas entry, but the shared "addr" nested inside
+	// both should be hoisted out and deduplicated into a third entry
+	// rather than inlined twice.
+	if got := len(doc.Components.Schemas); got != 3 {
+		t.Errorf("expected 3 components/schemas entries (2 bodies + 1 shared nested object), got %d: %v", got, doc.Components.Schemas)
+	}
+}
+
+func TestYAMLQuoting(t *testing.T) {
+	data, err := MarshalYAML(map[string]interface{}{
+		"default": "true",
+		"id":      "123",
+		"name":    "ok",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+
+	for _, want := range []string{`default: "true"`, `id: "123"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(out, "name: ok\n") {
+		t.Errorf("expected an unquoted plain string, got:\n%s", out)
+	}
+}
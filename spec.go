@@ -0,0 +1,46 @@
+package crud
+
+// Spec describes a single route: where it is mounted, what validates
+// its request, and the handler (plus optional adapter-specific
+// pre-handlers) that serves it.
+type Spec struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+
+	// Handler and PreHandlers are adapter-specific; each Adapter type
+	// switches on the concrete type it understands (e.g. http.Handler,
+	// mux.MiddlewareFunc).
+	Handler     interface{}
+	PreHandlers interface{}
+
+	Validate Validate
+
+	// Auth lists the roles allowed to call this route, as an OR of AND
+	// groups: Auth[i] is one acceptable set of roles, so
+	// [][]string{{"admin"}, {"editor", "owner"}} means admin OR (editor
+	// AND owner). A nil/empty Auth means no requirement.
+	Auth [][]string
+}
+
+// Validate groups the Field schemas that apply to the different parts
+// of a request, and optionally its response.
+type Validate struct {
+	Query Field
+	Body  Field
+	Path  Field
+
+	// Responses maps an HTTP status code to the Field its response body
+	// must satisfy. Key 0 is the fallback ("default") schema, used when
+	// the actual status code has no entry of its own.
+	Responses map[int]Field
+}
+
+// Adapter wires a Router's Specs into a concrete HTTP framework (mux,
+// echo, ...) and serves the generated documentation. swagger and/or
+// openapi are nil depending on the router's option.SpecVersion.
+type Adapter interface {
+	Install(router *Router, spec *Spec) error
+	Serve(swagger *Swagger, openapi *OpenAPI, addr string) error
+}
@@ -0,0 +1,125 @@
+package crud
+
+import "strings"
+
+// Swagger is the root Swagger 2.0 document served at /swagger.json.
+type Swagger struct {
+	Swagger             string                    `json:"swagger"`
+	Info                SwaggerInfo               `json:"info"`
+	Paths               map[string]PathItem       `json:"paths"`
+	SecurityDefinitions map[string]SecurityScheme `json:"securityDefinitions,omitempty"`
+}
+
+// SecurityScheme describes how a role requirement is authenticated. The
+// actual check is left to option.AuthResolver, so this only documents
+// that a role-bearing credential (by convention, a bearer token) is
+// expected.
+type SecurityScheme struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	In   string `json:"in,omitempty"`
+}
+
+// SwaggerInfo is the Swagger document's "info" object.
+type SwaggerInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem maps HTTP methods (lowercase) to the Operation mounted at a
+// path.
+type PathItem map[string]Operation
+
+// Operation describes a single method on a path.
+type Operation struct {
+	Summary     string                   `json:"summary,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Parameters  []map[string]interface{} `json:"parameters,omitempty"`
+	Responses   map[string]interface{}   `json:"responses"`
+	Security    []map[string][]string    `json:"security,omitempty"`
+}
+
+// authSecurityScheme is the name under which role requirements are
+// published in securityDefinitions/security.
+const authSecurityScheme = "roles"
+
+func (r *Router) swagger() *Swagger {
+	doc := &Swagger{
+		Swagger: "2.0",
+		Info:    SwaggerInfo{Title: r.title, Version: r.version},
+		Paths:   map[string]PathItem{},
+	}
+
+	for _, spec := range r.specs {
+		item, ok := doc.Paths[spec.Path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[spec.Path] = item
+		}
+		item[strings.ToLower(spec.Method)] = Operation{
+			Summary:     spec.Summary,
+			Description: spec.Description,
+			Parameters:  swaggerParameters(spec.Validate),
+			Responses: map[string]interface{}{
+				"200": map[string]string{"description": "OK"},
+			},
+			Security: authSecurity(spec.Auth),
+		}
+
+		if len(spec.Auth) > 0 && doc.SecurityDefinitions == nil {
+			doc.SecurityDefinitions = map[string]SecurityScheme{
+				authSecurityScheme: {Type: "apiKey", Name: "Authorization", In: "header"},
+			}
+		}
+	}
+
+	return doc
+}
+
+// swaggerParameters renders a Spec's query, path, and body schemas as
+// Swagger 2.0 parameter objects.
+func swaggerParameters(v Validate) []map[string]interface{} {
+	var params []map[string]interface{}
+	params = append(params, namedParameters("query", v.Query)...)
+	params = append(params, namedParameters("path", v.Path)...)
+
+	if v.Body.Initialized() {
+		params = append(params, map[string]interface{}{
+			"name":     "body",
+			"in":       "body",
+			"required": true,
+			"schema":   fieldSchema(v.Body, nil),
+		})
+	}
+
+	return params
+}
+
+func namedParameters(in string, obj Field) []map[string]interface{} {
+	if !obj.Initialized() {
+		return nil
+	}
+
+	var params []map[string]interface{}
+	for name, field := range obj.properties {
+		p := fieldSchema(field, nil)
+		p["name"] = name
+		p["in"] = in
+		if field.required {
+			p["required"] = true
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+func authSecurity(required [][]string) []map[string][]string {
+	if len(required) == 0 {
+		return nil
+	}
+	security := make([]map[string][]string, len(required))
+	for i, group := range required {
+		security[i] = map[string][]string{authSecurityScheme: group}
+	}
+	return security
+}
@@ -0,0 +1,34 @@
+package crud
+
+// SwaggerUiTemplate is the static HTML page served at "/" that loads
+// Swagger UI against /swagger.json.
+var SwaggerUiTemplate = []byte(`<!DOCTYPE html>
+<html>
+<head><title>API Docs</title></head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+<script>
+window.onload = function() {
+  SwaggerUIBundle({ url: "/swagger.json", dom_id: "#swagger-ui" })
+}
+</script>
+</body>
+</html>`)
+
+// OpenApiUiTemplate is the static HTML page served at "/" that loads
+// Swagger UI against /openapi.json, for routers configured with
+// option.SpecVersion(V3) only (no Swagger 2.0 document to point at).
+var OpenApiUiTemplate = []byte(`<!DOCTYPE html>
+<html>
+<head><title>API Docs</title></head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+<script>
+window.onload = function() {
+  SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" })
+}
+</script>
+</body>
+</html>`)
@@ -0,0 +1,360 @@
+package crud
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+)
+
+// Validate checks query, body, and path against the schemas in v,
+// rewriting query/body in place to apply defaults and strip unknown
+// properties. By default it reports only the first failure it finds,
+// as a ValidationError that errors.Is/errors.Unwrap see through to the
+// relevant sentinel (errRequired, errWrongType, ...). With
+// option.AggregateErrors(true) it instead returns every failure found
+// across the whole request as a ValidationErrors.
+func (r *Router) Validate(v Validate, query url.Values, body interface{}, path map[string]string) error {
+	var entries []ValidationError
+
+	if v.Path.Initialized() {
+		r.validateScalarObject(v.Path, path, "path", &entries)
+	}
+	if v.Query.Initialized() {
+		r.validateQuery(v.Query, query, &entries)
+	}
+	if v.Body.Initialized() {
+		if body == nil {
+			entries = append(entries, newValidationError("/", "required", errRequired, nil))
+		} else {
+			r.validateBody(v.Body, body, "", false, &entries)
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+	if r.opts.AggregateErrors {
+		return ValidationErrors(entries)
+	}
+	return entries[0]
+}
+
+// ValidateResponse validates a decoded response body against the Field
+// registered for status in responses, falling back to the Field at key
+// 0 when status has no entry of its own. It reuses the same engine as
+// request Body validation. A nil or absent responses entry is not an
+// error - undocumented responses aren't validated.
+func (r *Router) ValidateResponse(responses map[int]Field, status int, body interface{}) error {
+	field, ok := responses[status]
+	if !ok {
+		field, ok = responses[0]
+	}
+	if !ok || !field.Initialized() {
+		return nil
+	}
+
+	var entries []ValidationError
+	if body == nil {
+		entries = append(entries, newValidationError("/", "required", errRequired, nil))
+	} else {
+		r.validateBody(field, body, "", true, &entries)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+	if r.opts.AggregateErrors {
+		return ValidationErrors(entries)
+	}
+	return entries[0]
+}
+
+func resolveBool(def bool, override *bool) bool {
+	if override != nil {
+		return *override
+	}
+	return def
+}
+
+// validateScalarObject validates the flat string-keyed inputs shared by
+// path parameters (map[string]string) and, via validateQuery, query
+// parameters (url.Values).
+func (r *Router) validateScalarObject(obj Field, values map[string]string, ns string, entries *[]ValidationError) {
+	for name, field := range obj.properties {
+		raw, present := values[name]
+		if raw == "" {
+			present = false
+		}
+
+		if !present {
+			if field.required {
+				*entries = append(*entries, newValidationError(ns+"/"+name, "required", errRequired, nil))
+			}
+			continue
+		}
+
+		r.validateScalarString(field, raw, ns+"/"+name, entries)
+	}
+}
+
+func (r *Router) validateQuery(obj Field, query url.Values, entries *[]ValidationError) {
+	allowUnknown := resolveBool(r.opts.AllowUnknown, obj.allowUnknown)
+	stripUnknown := resolveBool(r.opts.StripUnknown, obj.stripUnknown)
+
+	for key := range query {
+		if _, ok := obj.properties[key]; ok {
+			continue
+		}
+		if stripUnknown {
+			query.Del(key)
+			continue
+		}
+		if !allowUnknown {
+			*entries = append(*entries, newValidationError("query/"+key, "unknown", errUnknown, query.Get(key)))
+		}
+	}
+
+	for name, field := range obj.properties {
+		raw, present := query[name]
+		value := ""
+		if present && len(raw) > 0 {
+			value = raw[0]
+		}
+		if value == "" {
+			present = false
+		}
+
+		if !present {
+			if field.hasDefault {
+				query.Set(name, fmt.Sprintf("%v", field.def))
+				continue
+			}
+			if field.required {
+				*entries = append(*entries, newValidationError("query/"+name, "required", errRequired, nil))
+			}
+			continue
+		}
+
+		if field.kind == KindArray {
+			r.validateQueryArray(field, name, raw, entries)
+			continue
+		}
+
+		r.validateScalarString(field, value, "query/"+name, entries)
+	}
+}
+
+func (r *Router) validateQueryArray(field Field, name string, raw []string, entries *[]ValidationError) {
+	path := "query/" + name
+	if field.hasMin && float64(len(raw)) < field.min {
+		*entries = append(*entries, newValidationError(path, "minimum", errMinimum, raw))
+	}
+	if field.hasMax && float64(len(raw)) > field.max {
+		*entries = append(*entries, newValidationError(path, "maximum", errMaximum, raw))
+	}
+	if field.items == nil {
+		return
+	}
+	for i, item := range raw {
+		r.validateScalarString(*field.items, item, fmt.Sprintf("%s/%d", path, i), entries)
+	}
+}
+
+func (r *Router) validateScalarString(field Field, raw, path string, entries *[]ValidationError) {
+	value, err := parseScalarString(field, raw)
+	if err != nil {
+		*entries = append(*entries, newValidationError(path, "type", err, raw))
+		return
+	}
+	if field.hasMin && isNumericKind(field.kind) && value.(float64) < field.min {
+		*entries = append(*entries, newValidationError(path, "minimum", errMinimum, raw))
+	}
+	if field.hasMax && isNumericKind(field.kind) && value.(float64) > field.max {
+		*entries = append(*entries, newValidationError(path, "maximum", errMaximum, raw))
+	}
+	if len(field.enum) > 0 && !enumContains(field.enum, raw) {
+		*entries = append(*entries, newValidationError(path, "enum", errEnumNotFound, raw))
+	}
+	if field.kind == KindString {
+		r.checkFormat(field, raw, path, entries)
+	}
+}
+
+func isNumericKind(k Kind) bool {
+	return k == KindNumber || k == KindInteger
+}
+
+func parseScalarString(field Field, raw string) (interface{}, error) {
+	switch field.kind {
+	case KindString:
+		return raw, nil
+	case KindNumber:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errWrongType
+		}
+		return n, nil
+	case KindInteger:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil || n != math.Trunc(n) {
+			return nil, errWrongType
+		}
+		return n, nil
+	case KindBoolean:
+		switch raw {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		default:
+			return nil, errWrongType
+		}
+	default:
+		return raw, nil
+	}
+}
+
+func enumContains(enum []interface{}, raw string) bool {
+	for _, e := range enum {
+		if fmt.Sprintf("%v", e) == raw {
+			return true
+		}
+	}
+	return false
+}
+
+// validateBody recursively validates a decoded JSON value (map[string]
+// interface{}, []interface{}, or a scalar) against field, rewriting it
+// in place to apply defaults and strip unknown/readOnly/writeOnly
+// properties. isResponse distinguishes a response body (where ReadOnly
+// properties are required and WriteOnly properties are stripped before
+// sending) from a request body (the reverse).
+func (r *Router) validateBody(field Field, value interface{}, path string, isResponse bool, entries *[]ValidationError) {
+	switch field.kind {
+	case KindObject:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			*entries = append(*entries, newValidationError(pointerOrRoot(path), "type", errWrongType, value))
+			return
+		}
+
+		allowUnknown := resolveBool(r.opts.AllowUnknown, field.allowUnknown)
+		stripUnknown := resolveBool(r.opts.StripUnknown, field.stripUnknown)
+		for key := range obj {
+			if _, ok := field.properties[key]; ok {
+				continue
+			}
+			if stripUnknown {
+				delete(obj, key)
+				continue
+			}
+			if !allowUnknown {
+				*entries = append(*entries, newValidationError(path+"/"+key, "unknown", errUnknown, obj[key]))
+			}
+		}
+
+		for name, sub := range field.properties {
+			child, present := obj[name]
+
+			if present && !isResponse && sub.readOnly {
+				if stripUnknown {
+					delete(obj, name)
+					continue
+				}
+				*entries = append(*entries, newValidationError(path+"/"+name, "readOnly", errReadOnly, child))
+				continue
+			}
+			if present && isResponse && sub.writeOnly {
+				delete(obj, name)
+				continue
+			}
+
+			if !present || child == nil {
+				if sub.hasDefault {
+					obj[name] = sub.def
+					continue
+				}
+				required := sub.required
+				if !isResponse && sub.readOnly {
+					required = false
+				}
+				if isResponse && sub.writeOnly {
+					required = false
+				}
+				if required {
+					*entries = append(*entries, newValidationError(path+"/"+name, "required", errRequired, nil))
+				}
+				continue
+			}
+			r.validateBody(sub, child, path+"/"+name, isResponse, entries)
+		}
+	case KindArray:
+		arr, ok := value.([]interface{})
+		if !ok {
+			*entries = append(*entries, newValidationError(pointerOrRoot(path), "type", errWrongType, value))
+			return
+		}
+		if field.hasMin && float64(len(arr)) < field.min {
+			*entries = append(*entries, newValidationError(pointerOrRoot(path), "minimum", errMinimum, value))
+		}
+		if field.hasMax && float64(len(arr)) > field.max {
+			*entries = append(*entries, newValidationError(pointerOrRoot(path), "maximum", errMaximum, value))
+		}
+		if field.items != nil {
+			for i, item := range arr {
+				r.validateBody(*field.items, item, fmt.Sprintf("%s/%d", path, i), isResponse, entries)
+			}
+		}
+	default:
+		if err := checkScalarJSON(field, value); err != nil {
+			*entries = append(*entries, newValidationError(pointerOrRoot(path), "type", err, value))
+			return
+		}
+		if len(field.enum) > 0 && !enumContainsJSON(field.enum, value) {
+			*entries = append(*entries, newValidationError(pointerOrRoot(path), "enum", errEnumNotFound, value))
+		}
+		if field.kind == KindString {
+			r.checkFormat(field, value.(string), pointerOrRoot(path), entries)
+		}
+	}
+}
+
+func pointerOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func checkScalarJSON(field Field, value interface{}) error {
+	switch field.kind {
+	case KindString:
+		if _, ok := value.(string); !ok {
+			return errWrongType
+		}
+	case KindNumber:
+		if _, ok := value.(float64); !ok {
+			return errWrongType
+		}
+	case KindInteger:
+		n, ok := value.(float64)
+		if !ok || n != math.Trunc(n) {
+			return errWrongType
+		}
+	case KindBoolean:
+		if _, ok := value.(bool); !ok {
+			return errWrongType
+		}
+	}
+	return nil
+}
+
+func enumContainsJSON(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprintf("%v", e) == fmt.Sprintf("%v", value) {
+			return true
+		}
+	}
+	return false
+}
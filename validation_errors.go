@@ -0,0 +1,72 @@
+package crud
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError describes a single validation failure. Path is a JSON
+// Pointer into the value that failed: a leading-slash pointer into the
+// body (e.g. "/complex1/array/0/id"), or a "query/"- or "path/"-prefixed
+// pointer for those request parts (e.g. "query/testquery", "path/id").
+type ValidationError struct {
+	Path    string      `json:"path"`
+	Field   string      `json:"field"`
+	Kind    string      `json:"kind"`
+	Message string      `json:"message"`
+	Value   interface{} `json:"value,omitempty"`
+
+	err error
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Unwrap exposes the sentinel error (errRequired, errWrongType, ...)
+// behind this failure, so errors.Is works against it.
+func (e ValidationError) Unwrap() error { return e.err }
+
+// ValidationErrors is the aggregate of every failure found while
+// validating a request with option.AggregateErrors(true). It implements
+// error, Unwrap() []error (so errors.Is/errors.As see through to the
+// underlying sentinels), and can be marshaled to JSON as
+// {"errors":[...]}.
+type ValidationErrors []ValidationError
+
+// Error implements error.
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msgs := make([]string, len(e))
+	for i, entry := range e {
+		msgs[i] = entry.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap lets errors.Is/errors.As walk into every entry's sentinel.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, entry := range e {
+		errs[i] = entry
+	}
+	return errs
+}
+
+func newValidationError(path, kind string, err error, value interface{}) ValidationError {
+	field := path
+	if i := strings.LastIndexByte(path, '/'); i >= 0 {
+		field = path[i+1:]
+	}
+	return ValidationError{
+		Path:    path,
+		Field:   field,
+		Kind:    kind,
+		Message: err.Error(),
+		Value:   value,
+		err:     err,
+	}
+}
@@ -0,0 +1,143 @@
+package crud
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MarshalYAML renders v as YAML. It round-trips v through JSON first,
+// so any json-tagged struct (such as *OpenAPI) works without needing
+// its own YAML tags; this keeps /openapi.yaml in lockstep with
+// /openapi.json without pulling in a YAML dependency.
+func MarshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	writeYAML(&b, generic, 0)
+	return []byte(b.String()), nil
+}
+
+func writeYAML(b *strings.Builder, v interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			b.WriteString(pad + "{}\n")
+			return
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			writeYAMLField(b, pad, k, val[k], indent)
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			b.WriteString(pad + "[]\n")
+			return
+		}
+		for _, item := range val {
+			writeYAMLListItem(b, pad, item, indent)
+		}
+	default:
+		b.WriteString(pad + yamlScalar(val) + "\n")
+	}
+}
+
+func writeYAMLField(b *strings.Builder, pad, key string, value interface{}, indent int) {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		b.WriteString(pad + yamlKey(key) + ":\n")
+		writeYAML(b, value, indent+1)
+	default:
+		b.WriteString(pad + yamlKey(key) + ": " + yamlScalar(value) + "\n")
+	}
+}
+
+func writeYAMLListItem(b *strings.Builder, pad string, item interface{}, indent int) {
+	switch v := item.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for i, k := range keys {
+			prefix := pad + "  "
+			if i == 0 {
+				prefix = pad + "- "
+			}
+			writeYAMLField(b, prefix, k, v[k], indent+1)
+		}
+	default:
+		b.WriteString(pad + "- " + yamlScalar(v) + "\n")
+	}
+}
+
+func yamlKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	return key
+}
+
+func yamlScalar(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return yamlQuoteString(val)
+	case bool:
+		return fmt.Sprintf("%v", val)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// yamlReservedWords are scalars a standard YAML parser reads back as a
+// bool or null rather than a string, regardless of case.
+var yamlReservedWords = map[string]bool{
+	"true": true, "false": true, "null": true, "yes": true, "no": true,
+}
+
+func yamlQuoteString(s string) string {
+	if s == "" {
+		return `""`
+	}
+	if strings.ContainsAny(s, ":#{}[]&*!|>'\"%@`\n") {
+		return fmt.Sprintf("%q", s)
+	}
+	if yamlReservedWords[strings.ToLower(s)] {
+		return fmt.Sprintf("%q", s)
+	}
+	if isYAMLNumericLooking(s) {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// isYAMLNumericLooking reports whether a YAML parser would read s back
+// as a number instead of a string.
+func isYAMLNumericLooking(s string) bool {
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	return false
+}